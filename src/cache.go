@@ -0,0 +1,148 @@
+package main
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Cache stores raw Kakao API response bodies keyed by request URL so that
+// repeated lookups for the same place/query within the TTL skip the network
+// round trip entirely.
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// cacheKeyFor derives a stable cache key from the request URL and the
+// auth scope (the API key) it was made with, so entries never leak across keys.
+func cacheKeyFor(authScope, url string) string {
+	sum := sha256.Sum256([]byte(authScope + "|" + url))
+	return hex.EncodeToString(sum[:])
+}
+
+// --- In-memory LRU cache ---
+
+type memoryCacheEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// MemoryCache is a bounded, TTL-aware LRU cache used when no Redis backend
+// is configured.
+type MemoryCache struct {
+	mu       sync.Mutex
+	capacity int
+	ll       *list.List
+	items    map[string]*list.Element
+	onEvict  func(key string)
+}
+
+func NewMemoryCache(capacity int) *MemoryCache {
+	return &MemoryCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// SetEvictHandler registers a callback invoked with the key of every entry
+// evicted from the cache, whether by TTL expiry or by falling off the LRU.
+func (c *MemoryCache) SetEvictHandler(onEvict func(key string)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onEvict = onEvict
+}
+
+func (c *MemoryCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := elem.Value.(*memoryCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.ll.Remove(elem)
+		delete(c.items, key)
+		if c.onEvict != nil {
+			c.onEvict(key)
+		}
+		return nil, false, nil
+	}
+	c.ll.MoveToFront(elem)
+	return entry.value, true, nil
+}
+
+func (c *MemoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		entry := elem.Value.(*memoryCacheEntry)
+		entry.value = value
+		entry.expiresAt = time.Now().Add(ttl)
+		c.ll.MoveToFront(elem)
+		return nil
+	}
+
+	elem := c.ll.PushFront(&memoryCacheEntry{key: key, value: value, expiresAt: time.Now().Add(ttl)})
+	c.items[key] = elem
+
+	if c.capacity > 0 && c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			evictedKey := oldest.Value.(*memoryCacheEntry).key
+			c.ll.Remove(oldest)
+			delete(c.items, evictedKey)
+			if c.onEvict != nil {
+				c.onEvict(evictedKey)
+			}
+		}
+	}
+	return nil
+}
+
+// --- Redis-backed cache ---
+
+// RedisCache is used when REDIS_ADDR is set, letting cache entries survive
+// restarts and be shared across multiple server instances.
+type RedisCache struct {
+	client *redis.Client
+}
+
+func NewRedisCache(addr, password string, db int) *RedisCache {
+	return &RedisCache{
+		client: redis.NewClient(&redis.Options{
+			Addr:     addr,
+			Password: password,
+			DB:       db,
+		}),
+	}
+}
+
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := c.client.Get(ctx, key).Bytes()
+	if err == redis.Nil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("redis get failed: %w", err)
+	}
+	return value, true, nil
+}
+
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := c.client.Set(ctx, key, value, ttl).Err(); err != nil {
+		return fmt.Errorf("redis set failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+const defaultEnrichConcurrency = 8
+
+// kakaoRateLimiter caps the combined QPS of web/image enrichment requests
+// fired by the worker pool so a large place list can't blow through Kakao's
+// quota.
+var kakaoRateLimiter *rate.Limiter
+
+func initRateLimiter() *rate.Limiter {
+	qps := 10.0
+	if v := os.Getenv("KAKAO_QPS"); v != "" {
+		if n, err := strconv.ParseFloat(v, 64); err == nil && n > 0 {
+			qps = n
+		}
+	}
+	return rate.NewLimiter(rate.Limit(qps), int(qps))
+}
+
+func enrichConcurrency() int {
+	concurrency := defaultEnrichConcurrency
+	if v := os.Getenv("KAKAO_ENRICH_CONCURRENCY"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			concurrency = n
+		}
+	}
+	return concurrency
+}
+
+type enrichJob struct {
+	index int
+	doc   MapDocument
+}
+
+type enrichResult struct {
+	index  int
+	doc    MapDocument
+	result KakaoLocalSearchResult
+	err    error
+}
+
+// enrichPlaces fans a place list out across a bounded worker pool (sized by
+// KAKAO_ENRICH_CONCURRENCY), fetching web and image details for each place.
+// Results stream back on the returned channel as they complete, independent
+// of input order. Cancelling ctx aborts any work still in flight.
+func enrichPlaces(ctx context.Context, mapDocuments []MapDocument) <-chan enrichResult {
+	jobs := make(chan enrichJob)
+	results := make(chan enrichResult)
+
+	var workers sync.WaitGroup
+	for i := 0; i < enrichConcurrency(); i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for job := range jobs {
+				select {
+				case <-ctx.Done():
+					return
+				case results <- enrichPlace(ctx, job):
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for i, doc := range mapDocuments {
+			select {
+			case <-ctx.Done():
+				return
+			case jobs <- enrichJob{index: i, doc: doc}:
+			}
+		}
+	}()
+
+	go func() {
+		workers.Wait()
+		close(results)
+	}()
+
+	return results
+}
+
+func enrichPlace(ctx context.Context, job enrichJob) enrichResult {
+	var wg sync.WaitGroup
+	var webDocs []WebDocument
+	var imgDoc ImageDocument
+	var webErr, imgErr error
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if webErr = kakaoRateLimiter.Wait(ctx); webErr != nil {
+			return
+		}
+		webDocs, webErr = fetchWebDocuments(ctx, job.doc.PlaceName)
+	}()
+	go func() {
+		defer wg.Done()
+		if imgErr = kakaoRateLimiter.Wait(ctx); imgErr != nil {
+			return
+		}
+		imgDoc, imgErr = fetchImageDocument(ctx, job.doc.PlaceName)
+	}()
+	wg.Wait()
+
+	if webErr != nil || imgErr != nil {
+		return enrichResult{index: job.index, doc: job.doc, err: fmt.Errorf("web_error=%v image_error=%v", webErr, imgErr)}
+	}
+
+	placeID := placeIDFor(job.doc)
+	result := KakaoLocalSearchResult{
+		PlaceID:      placeID,
+		PlaceName:    job.doc.PlaceName,
+		AddressName:  job.doc.AddressName,
+		CategoryName: job.doc.CategoryName,
+		PlaceURL:     job.doc.PlaceURL,
+		Phone:        job.doc.Phone,
+		Comments:     webDocs,
+		ImageURL:     imgDoc.ImageURL,
+	}
+	return enrichResult{index: job.index, doc: job.doc, result: result}
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// --- Kakao 지오코딩 엔드포인트 ---
+
+const (
+	kakaoCoord2AddressURL    = "https://dapi.kakao.com/v2/local/geo/coord2address.json"
+	kakaoCoord2RegionCodeURL = "https://dapi.kakao.com/v2/local/geo/coord2regioncode.json"
+	kakaoAddressSearchURL    = "https://dapi.kakao.com/v2/local/search/address.json"
+)
+
+// --- 데이터 구조 정의 (Structs) ---
+
+type CoordToAddressSchema struct {
+	X          string `json:"x" jsonschema:"Longitude of the point to reverse-geocode."`
+	Y          string `json:"y" jsonschema:"Latitude of the point to reverse-geocode."`
+	InputCoord string `json:"input_coord,omitempty" jsonschema:"Coordinate system of x,y: WGS84 (default), WCONGNAMUL, CONGNAMUL, WTM, TM, KTM, UTM, BESSEL, WKTM or WUTM."`
+}
+
+type AddressToCoordSchema struct {
+	Query string `json:"query" jsonschema:"Korean road-name or jibun (lot-number) address to geocode."`
+}
+
+type RoadAddress struct {
+	AddressName  string `json:"address_name"`
+	BuildingName string `json:"building_name"`
+	ZoneNo       string `json:"zone_no"`
+}
+
+type JibunAddress struct {
+	AddressName   string `json:"address_name"`
+	MainAddressNo string `json:"main_address_no"`
+	SubAddressNo  string `json:"sub_address_no"`
+}
+
+type RegionCode struct {
+	RegionType       string `json:"region_type"`
+	Code             string `json:"code"`
+	AddressName      string `json:"address_name"`
+	Region1DepthName string `json:"region_1depth_name"`
+	Region2DepthName string `json:"region_2depth_name"`
+	Region3DepthName string `json:"region_3depth_name"`
+}
+
+type coord2AddressDocument struct {
+	RoadAddress RoadAddress  `json:"road_address"`
+	Address     JibunAddress `json:"address"`
+}
+
+type coord2AddressResponse struct {
+	Documents []coord2AddressDocument `json:"documents"`
+}
+
+type coord2RegionCodeResponse struct {
+	Documents []RegionCode `json:"documents"`
+}
+
+// CoordToAddressResult combines the road address, jibun address, and region
+// hierarchy for a single coordinate.
+type CoordToAddressResult struct {
+	RoadAddress  *RoadAddress  `json:"road_address,omitempty"`
+	JibunAddress *JibunAddress `json:"jibun_address,omitempty"`
+	Region       *RegionCode   `json:"region,omitempty"`
+}
+
+type AddressSearchDocument struct {
+	AddressName string       `json:"address_name"`
+	AddressType string       `json:"address_type"`
+	X           string       `json:"x"`
+	Y           string       `json:"y"`
+	RoadAddress *RoadAddress `json:"road_address,omitempty"`
+}
+
+type addressSearchResponse struct {
+	Documents []AddressSearchDocument `json:"documents"`
+}
+
+// --- Kakao API 호출 헬퍼 함수 ---
+
+func fetchCoordToAddress(ctx context.Context, x, y, inputCoord string) (*CoordToAddressResult, error) {
+	values := url.Values{}
+	values.Set("x", x)
+	values.Set("y", y)
+	if inputCoord != "" {
+		values.Set("input_coord", inputCoord)
+	}
+
+	var addressResp coord2AddressResponse
+	addressURL := fmt.Sprintf("%s?%s", kakaoCoord2AddressURL, values.Encode())
+	if err := makeKakaoRequest(ctx, addressURL, &addressResp, mapCacheTTL); err != nil {
+		return nil, err
+	}
+
+	var regionResp coord2RegionCodeResponse
+	regionURL := fmt.Sprintf("%s?%s", kakaoCoord2RegionCodeURL, values.Encode())
+	if err := makeKakaoRequest(ctx, regionURL, &regionResp, mapCacheTTL); err != nil {
+		return nil, err
+	}
+
+	result := &CoordToAddressResult{}
+	if len(addressResp.Documents) > 0 {
+		doc := addressResp.Documents[0]
+		result.RoadAddress = &doc.RoadAddress
+		result.JibunAddress = &doc.Address
+	}
+	if len(regionResp.Documents) > 0 {
+		result.Region = &regionResp.Documents[0]
+	}
+
+	logger.Info("Fetched coord-to-address result", "x", x, "y", y)
+	return result, nil
+}
+
+func fetchAddressToCoord(ctx context.Context, query string) ([]AddressSearchDocument, error) {
+	values := url.Values{}
+	values.Set("query", query)
+
+	var response addressSearchResponse
+	reqURL := fmt.Sprintf("%s?%s", kakaoAddressSearchURL, values.Encode())
+	if err := makeKakaoRequest(ctx, reqURL, &response, mapCacheTTL); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Fetched address-to-coord results", "query", query, "count", len(response.Documents))
+	return response.Documents, nil
+}
+
+// --- MCP 도구 콜백 함수 ---
+
+func coordToAddressTool(ctx context.Context, req *mcp.CallToolRequest, options CoordToAddressSchema) (*mcp.CallToolResult, any, error) {
+	logger.Info("coordToAddressTool called", "x", options.X, "y", options.Y)
+
+	if kakaoAPIKey == "" {
+		logger.Error("Tool Execution Failed: KAKAO_API_KEY is not configured.")
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: "Tool Execution Failed: KAKAO_API_KEY is not configured."}},
+		}, nil, nil
+	}
+	if options.X == "" || options.Y == "" {
+		logger.Warn("x or y is empty")
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: "x and y are required"}},
+		}, nil, nil
+	}
+
+	result, err := fetchCoordToAddress(ctx, options.X, options.Y, options.InputCoord)
+	if err != nil {
+		logger.Error("Failed to fetch coord-to-address result", "x", options.X, "y", options.Y, "error", err)
+		return nil, nil, fmt.Errorf("failed to fetch coord-to-address result: %w", err)
+	}
+
+	resultBytes, err := json.Marshal(result)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal coord-to-address result: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(resultBytes)}},
+	}, nil, nil
+}
+
+func addressToCoordTool(ctx context.Context, req *mcp.CallToolRequest, options AddressToCoordSchema) (*mcp.CallToolResult, any, error) {
+	logger.Info("addressToCoordTool called", "query", options.Query)
+
+	if kakaoAPIKey == "" {
+		logger.Error("Tool Execution Failed: KAKAO_API_KEY is not configured.")
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: "Tool Execution Failed: KAKAO_API_KEY is not configured."}},
+		}, nil, nil
+	}
+	if options.Query == "" {
+		logger.Warn("Query is empty")
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: "Query is empty"}},
+		}, nil, nil
+	}
+
+	documents, err := fetchAddressToCoord(ctx, options.Query)
+	if err != nil {
+		logger.Error("Failed to fetch address-to-coord result", "query", options.Query, "error", err)
+		return nil, nil, fmt.Errorf("failed to fetch address-to-coord result: %w", err)
+	}
+
+	resultBytes, err := json.Marshal(documents)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal address-to-coord result: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(resultBytes)}},
+	}, nil, nil
+}
+
+// --- 도구 등록 ---
+
+func registerCoordToAddressTool(s *mcp.Server) {
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "kakao_map_coord_to_address",
+		Description: "Reverse-geocodes WGS84 coordinates into a road address, jibun address, and region hierarchy.",
+	}, coordToAddressTool)
+}
+
+func registerAddressToCoordTool(s *mcp.Server) {
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "kakao_map_address_to_coord",
+		Description: "Geocodes a Korean address into WGS84 coordinates.",
+	}, addressToCoordTool)
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// HistoryEntry is one recorded kakao_map_place_recommender invocation.
+type HistoryEntry struct {
+	ID          int64     `json:"id"`
+	Timestamp   time.Time `json:"timestamp"`
+	Query       string    `json:"query"`
+	ResultCount int       `json:"result_count"`
+	LatencyMS   int64     `json:"latency_ms"`
+	Places      string    `json:"places"`
+}
+
+// HistoryStore persists search activity to SQLite so kakao_map_history can
+// answer "what did I search for" questions.
+type HistoryStore struct {
+	db *sql.DB
+}
+
+// initHistoryStore opens and migrates the history database at
+// HISTORY_DB_PATH. It returns a nil store, with no error, when the env var
+// is unset so persistence can be skipped entirely.
+func initHistoryStore() (*HistoryStore, error) {
+	path := os.Getenv("HISTORY_DB_PATH")
+	if path == "" {
+		logger.Info("HISTORY_DB_PATH not set, activity history persistence is disabled")
+		return nil, nil
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history database: %w", err)
+	}
+
+	store := &HistoryStore{db: db}
+	if err := store.migrate(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to migrate history database: %w", err)
+	}
+
+	logger.Info("Activity history persistence enabled", "path", path)
+	return store, nil
+}
+
+func (s *HistoryStore) migrate() error {
+	_, err := s.db.Exec(`
+CREATE TABLE IF NOT EXISTS search_history (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	timestamp TEXT NOT NULL,
+	query TEXT NOT NULL,
+	result_count INTEGER NOT NULL,
+	latency_ms INTEGER NOT NULL,
+	places TEXT NOT NULL
+)`)
+	return err
+}
+
+// Record logs one search invocation. places is a JSON-encoded list of the
+// resolved place names.
+func (s *HistoryStore) Record(ctx context.Context, query string, resultCount int, latency time.Duration, places string) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO search_history (timestamp, query, result_count, latency_ms, places) VALUES (?, ?, ?, ?, ?)`,
+		time.Now().UTC().Format(time.RFC3339), query, resultCount, latency.Milliseconds(), places,
+	)
+	return err
+}
+
+// Query returns recorded searches whose query contains substring (when
+// non-empty) and whose timestamp falls within [from, to] (when non-zero),
+// most recent first.
+func (s *HistoryStore) Query(ctx context.Context, substring string, from, to time.Time) ([]HistoryEntry, error) {
+	sqlQuery := `SELECT id, timestamp, query, result_count, latency_ms, places FROM search_history WHERE 1=1`
+	var args []interface{}
+
+	if substring != "" {
+		sqlQuery += " AND query LIKE ?"
+		args = append(args, "%"+substring+"%")
+	}
+	if !from.IsZero() {
+		sqlQuery += " AND timestamp >= ?"
+		args = append(args, from.UTC().Format(time.RFC3339))
+	}
+	if !to.IsZero() {
+		sqlQuery += " AND timestamp <= ?"
+		args = append(args, to.UTC().Format(time.RFC3339))
+	}
+	sqlQuery += " ORDER BY timestamp DESC"
+
+	rows, err := s.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []HistoryEntry
+	for rows.Next() {
+		var e HistoryEntry
+		var ts string
+		if err := rows.Scan(&e.ID, &ts, &e.Query, &e.ResultCount, &e.LatencyMS, &e.Places); err != nil {
+			return nil, err
+		}
+		e.Timestamp, err = time.Parse(time.RFC3339, ts)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// historyStore is nil when HISTORY_DB_PATH is unset, in which case search
+// history is simply not recorded.
+var historyStore *HistoryStore
+
+// --- MCP 도구 콜백 함수 ---
+
+type HistorySchema struct {
+	Substring string `json:"substring,omitempty" jsonschema:"Only return searches whose query contains this substring."`
+	From      string `json:"from,omitempty" jsonschema:"RFC3339 timestamp; only return searches at or after this time."`
+	To        string `json:"to,omitempty" jsonschema:"RFC3339 timestamp; only return searches at or before this time."`
+}
+
+func historyTool(ctx context.Context, req *mcp.CallToolRequest, options HistorySchema) (*mcp.CallToolResult, any, error) {
+	logger.Info("historyTool called", "substring", options.Substring, "from", options.From, "to", options.To)
+
+	if historyStore == nil {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: "Activity history is not enabled: set HISTORY_DB_PATH to enable it."}},
+		}, nil, nil
+	}
+
+	var from, to time.Time
+	var err error
+	if options.From != "" {
+		if from, err = time.Parse(time.RFC3339, options.From); err != nil {
+			return &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("invalid from: %v", err)}},
+			}, nil, nil
+		}
+	}
+	if options.To != "" {
+		if to, err = time.Parse(time.RFC3339, options.To); err != nil {
+			return &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("invalid to: %v", err)}},
+			}, nil, nil
+		}
+	}
+
+	entries, err := historyStore.Query(ctx, options.Substring, from, to)
+	if err != nil {
+		logger.Error("Failed to query activity history", "error", err)
+		return nil, nil, fmt.Errorf("failed to query activity history: %w", err)
+	}
+
+	body, err := json.Marshal(entries)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal history entries: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(body)}},
+	}, nil, nil
+}
+
+func registerHistoryTool(s *mcp.Server) {
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "kakao_map_history",
+		Description: "Recalls past kakao_map_place_recommender searches, optionally filtered by a query substring and date range.",
+	}, historyTool)
+}
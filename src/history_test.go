@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func newTestHistoryStore(t *testing.T) *HistoryStore {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	store := &HistoryStore{db: db}
+	if err := store.migrate(); err != nil {
+		t.Fatalf("migrate() error = %v", err)
+	}
+	return store
+}
+
+func TestHistoryStoreRecordAndQuery(t *testing.T) {
+	store := newTestHistoryStore(t)
+	ctx := context.Background()
+
+	if err := store.Record(ctx, "홍대 카페", 5, 120*time.Millisecond, `["카페 A","카페 B"]`); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if err := store.Record(ctx, "강남 맛집", 3, 80*time.Millisecond, `["맛집 A"]`); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	entries, err := store.Query(ctx, "카페", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("Query() returned %d entries, want 1", len(entries))
+	}
+	if entries[0].Query != "홍대 카페" {
+		t.Errorf("Query() got query %q, want %q", entries[0].Query, "홍대 카페")
+	}
+	if entries[0].ResultCount != 5 {
+		t.Errorf("Query() got result_count %d, want 5", entries[0].ResultCount)
+	}
+}
+
+func TestHistoryStoreQueryDateRange(t *testing.T) {
+	store := newTestHistoryStore(t)
+	ctx := context.Background()
+
+	if err := store.Record(ctx, "제주 숙소", 2, 50*time.Millisecond, `[]`); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	future := time.Now().Add(24 * time.Hour)
+	entries, err := store.Query(ctx, "", future, time.Time{})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Query() with a future `from` returned %d entries, want 0", len(entries))
+	}
+}
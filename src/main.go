@@ -4,23 +4,35 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
-	"sync"
+	"strconv"
 	"time"
 
 	// 실제 존재하는 공식 SDK 경로
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 // --- 데이터 구조 정의 (Structs) ---
 
 type SearchSchema struct {
-	Query string `json:"query" jsonschema:"Korean keywords for searching places in South Korea."`
+	Query             string `json:"query" jsonschema:"Korean keywords for searching places in South Korea."`
+	CategoryGroupCode string `json:"category_group_code,omitempty" jsonschema:"Kakao category group code to filter results by, e.g. FD6 (restaurants) or CE7 (cafes)."`
+	X                 string `json:"x,omitempty" jsonschema:"Longitude (WGS84) of the center point, used with y for radius search and distance sort."`
+	Y                 string `json:"y,omitempty" jsonschema:"Latitude (WGS84) of the center point, used with x for radius search and distance sort."`
+	Radius            int    `json:"radius,omitempty" jsonschema:"Search radius in meters around x,y (1-20000). Requires x and y."`
+	Rect              string `json:"rect,omitempty" jsonschema:"Bounding box as 'x1,y1,x2,y2' (bottom-left lon/lat, top-right lon/lat)."`
+	Page              int    `json:"page,omitempty" jsonschema:"Last page to fetch (1-45). Results are accumulated from page 1 up to this page. Defaults to 1."`
+	Size              int    `json:"size,omitempty" jsonschema:"Results per page (1-15). Defaults to 15."`
+	Sort              string `json:"sort,omitempty" jsonschema:"Sort order: accuracy (default) or distance (requires x and y)."`
 }
 
 type MapDocument struct {
+	ID           string `json:"id"`
 	PlaceName    string `json:"place_name"`
 	AddressName  string `json:"address_name"`
 	CategoryName string `json:"category_name"`
@@ -28,8 +40,15 @@ type MapDocument struct {
 	Phone        string `json:"phone"`
 }
 
+type SearchMeta struct {
+	TotalCount    int  `json:"total_count"`
+	PageableCount int  `json:"pageable_count"`
+	IsEnd         bool `json:"is_end"`
+}
+
 type KakaoLocalSearchResponse struct {
 	Documents []MapDocument `json:"documents"`
+	Meta      SearchMeta    `json:"meta"`
 }
 
 type WebDocument struct {
@@ -50,6 +69,7 @@ type DaumImageSearchResponse struct {
 }
 
 type KakaoLocalSearchResult struct {
+	PlaceID      string        `json:"place_id"`
 	PlaceName    string        `json:"place_name"`
 	AddressName  string        `json:"address_name"`
 	CategoryName string        `json:"category_name"`
@@ -62,15 +82,23 @@ type KakaoLocalSearchResult struct {
 // --- 전역 변수 및 상수 ---
 
 var (
-	kakaoAPIKey string
-	httpClient  = &http.Client{Timeout: 10 * time.Second}
-	logger      *slog.Logger
+	kakaoAPIKey   string
+	httpClient    = &http.Client{Timeout: 10 * time.Second}
+	logger        *slog.Logger
+	responseCache Cache
+	mcpServer     *mcp.Server
 )
 
 const (
 	kakaoMapURL   = "https://dapi.kakao.com/v2/local/search/keyword.json"
 	daumSearchURL = "https://dapi.kakao.com/v2/search"
-	systemPrompt  = `
+
+	mapCacheTTL   = 10 * time.Minute
+	webCacheTTL   = 1 * time.Hour
+	imageCacheTTL = 24 * time.Hour
+
+	serviceUserAgent = "mcp-server-kakao-map-go/0.0.1 (+https://github.com/Mireu-Lab/mcp-server-kakao-map)"
+	systemPrompt     = `
 Using the provided JSON results, compile a detailed and visually appealing Markdown summary for the user.
 
 Each place **MUST** include:
@@ -89,14 +117,43 @@ Note:
 - Ensure all listed elements (title with link, image, address, category, contact, and summary) are always included for every place.`
 )
 
+// --- 캐시 초기화 ---
+
+// initCache builds the cache backend used by makeKakaoRequest: Redis when
+// REDIS_ADDR is configured, otherwise an in-memory LRU.
+func initCache() Cache {
+	if addr := os.Getenv("REDIS_ADDR"); addr != "" {
+		logger.Info("Using Redis cache backend for Kakao API responses", "addr", addr)
+		return NewRedisCache(addr, os.Getenv("REDIS_PASSWORD"), 0)
+	}
+
+	capacity := 1000
+	if v := os.Getenv("CACHE_CAPACITY"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			capacity = n
+		}
+	}
+	logger.Info("Using in-memory cache backend for Kakao API responses", "capacity", capacity)
+	return NewMemoryCache(capacity)
+}
+
 // --- Kakao API 호출 헬퍼 함수 ---
 
-func makeKakaoRequest(ctx context.Context, url string, target interface{}) error {
+func makeKakaoRequest(ctx context.Context, url string, target interface{}, ttl time.Duration) error {
+	cacheKey := cacheKeyFor(kakaoAPIKey, url)
+	if body, ok, err := responseCache.Get(ctx, cacheKey); err == nil && ok {
+		cacheHits.Inc()
+		logger.Debug("Cache hit for Kakao API request", "url", url)
+		return json.Unmarshal(body, target)
+	}
+	cacheMisses.Inc()
+
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Authorization", "KakaoAK "+kakaoAPIKey)
+	req.Header.Set("User-Agent", serviceUserAgent)
 
 	logger.Debug("Making Kakao API request", "url", url)
 	resp, err := httpClient.Do(req)
@@ -111,25 +168,82 @@ func makeKakaoRequest(ctx context.Context, url string, target interface{}) error
 		return fmt.Errorf("API request failed with status: %s", resp.Status)
 	}
 
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if err := responseCache.Set(ctx, cacheKey, body, ttl); err != nil {
+		logger.Warn("Failed to write Kakao API response to cache", "url", url, "error", err)
+	}
+
 	logger.Debug("Kakao API request successful", "url", url, "status", resp.Status)
-	return json.NewDecoder(resp.Body).Decode(target)
+	return json.Unmarshal(body, target)
 }
 
-func fetchMapDocuments(ctx context.Context, query string) ([]MapDocument, error) {
-	var response KakaoLocalSearchResponse
-	url := fmt.Sprintf("%s?query=%s", kakaoMapURL, query)
-	err := makeKakaoRequest(ctx, url, &response)
-	if err != nil {
-		return nil, err
+func fetchMapDocuments(ctx context.Context, opts SearchSchema) ([]MapDocument, error) {
+	size := opts.Size
+	if size <= 0 || size > 15 {
+		size = 15
 	}
-	logger.Info("Fetched map documents", "query", query, "count", len(response.Documents))
-	return response.Documents, nil
+	lastPage := opts.Page
+	if lastPage <= 0 {
+		lastPage = 1
+	} else if lastPage > 45 {
+		lastPage = 45
+	}
+	target := size * lastPage
+
+	radius := opts.Radius
+	if radius < 0 {
+		radius = 0
+	} else if radius > 20000 {
+		radius = 20000
+	}
+
+	var documents []MapDocument
+	for page := 1; page <= lastPage; page++ {
+		values := url.Values{}
+		values.Set("query", opts.Query)
+		values.Set("page", strconv.Itoa(page))
+		values.Set("size", strconv.Itoa(size))
+		if opts.CategoryGroupCode != "" {
+			values.Set("category_group_code", opts.CategoryGroupCode)
+		}
+		if opts.X != "" && opts.Y != "" {
+			values.Set("x", opts.X)
+			values.Set("y", opts.Y)
+		}
+		if radius > 0 {
+			values.Set("radius", strconv.Itoa(radius))
+		}
+		if opts.Rect != "" {
+			values.Set("rect", opts.Rect)
+		}
+		if opts.Sort != "" {
+			values.Set("sort", opts.Sort)
+		}
+
+		var response KakaoLocalSearchResponse
+		reqURL := fmt.Sprintf("%s?%s", kakaoMapURL, values.Encode())
+		if err := makeKakaoRequest(ctx, reqURL, &response, mapCacheTTL); err != nil {
+			return nil, err
+		}
+		documents = append(documents, response.Documents...)
+
+		if response.Meta.IsEnd || len(documents) >= target {
+			break
+		}
+	}
+
+	logger.Info("Fetched map documents", "query", opts.Query, "count", len(documents))
+	return documents, nil
 }
 
 func fetchWebDocuments(ctx context.Context, query string) ([]WebDocument, error) {
 	var response DaumWebSearchResponse
 	url := fmt.Sprintf("%s/web?query=%s&page=1&size=5", daumSearchURL, query)
-	err := makeKakaoRequest(ctx, url, &response)
+	err := makeKakaoRequest(ctx, url, &response, webCacheTTL)
 	if err != nil {
 		return nil, err
 	}
@@ -140,7 +254,7 @@ func fetchWebDocuments(ctx context.Context, query string) ([]WebDocument, error)
 func fetchImageDocument(ctx context.Context, query string) (ImageDocument, error) {
 	var response DaumImageSearchResponse
 	url := fmt.Sprintf("%s/image?query=%s&page=1&size=1", daumSearchURL, query)
-	err := makeKakaoRequest(ctx, url, &response)
+	err := makeKakaoRequest(ctx, url, &response, imageCacheTTL)
 	if err != nil {
 		return ImageDocument{}, err
 	}
@@ -184,66 +298,88 @@ func searchTool(ctx context.Context, req *mcp.CallToolRequest, options SearchSch
 		Message:       systemPrompt,
 	})
 
-	mapDocuments, err := fetchMapDocuments(ctx, options.Query)
+	mapDocuments, err := fetchMapDocuments(ctx, options)
 	if err != nil {
 		logger.Error("Failed to fetch map documents", "query", options.Query, "error", err)
 		return nil, nil, fmt.Errorf("failed to fetch map documents: %w", err)
 	}
 
-	for _, doc := range mapDocuments {
-		logger.Debug("Processing map document", "place_name", doc.PlaceName)
-		var wg sync.WaitGroup
-		var webDocs []WebDocument
-		var imgDoc ImageDocument
-		var webErr, imgErr error
-
-		wg.Add(2)
-		go func(d MapDocument) {
-			defer wg.Done()
-			webDocs, webErr = fetchWebDocuments(ctx, d.PlaceName)
-		}(doc)
-		go func(d MapDocument) {
-			defer wg.Done()
-			imgDoc, imgErr = fetchImageDocument(ctx, d.PlaceName)
-		}(doc)
-		wg.Wait()
-
-		if webErr != nil || imgErr != nil {
-			logger.Error("Error fetching details for place", "place_name", doc.PlaceName, "web_error", webErr, "image_error", imgErr)
+	start := time.Now()
+	content := []mcp.Content{}
+	placeNames := []string{}
+	processed := 0
+
+	for res := range enrichPlaces(ctx, mapDocuments) {
+		if res.err != nil {
+			logger.Error("Error fetching details for place", "place_name", res.doc.PlaceName, "error", res.err)
 			continue
 		}
-
-		result := KakaoLocalSearchResult{
-			PlaceName:    doc.PlaceName,
-			AddressName:  doc.AddressName,
-			CategoryName: doc.CategoryName,
-			PlaceURL:     doc.PlaceURL,
-			Phone:        doc.Phone,
-			Comments:     webDocs,
-			ImageURL:     imgDoc.ImageURL,
+		if err := publishPlaceResource(ctx, res.result.PlaceID, res.result); err != nil {
+			logger.Warn("Failed to publish place resource", "place_name", res.doc.PlaceName, "error", err)
 		}
+		placeNames = append(placeNames, res.result.PlaceName)
 
-		resultBytes, err := json.Marshal(result)
+		resultBytes, err := json.Marshal(res.result)
 		if err != nil {
-			logger.Error("Failed to marshal result", "place_name", doc.PlaceName, "error", err)
+			logger.Error("Failed to marshal result", "place_name", res.doc.PlaceName, "error", err)
 			continue
 		}
 
+		content = append(content, &mcp.EmbeddedResource{
+			Resource: &mcp.ResourceContents{
+				URI:      placeResourceURI(res.result.PlaceID),
+				MIMEType: "application/json",
+				Text:     string(resultBytes),
+			},
+		})
+
+		processed++
 		// 3. 여기서도 serverSession 변수를 사용합니다.
-		logger.Debug("Notifying progress with search result", "place_name", doc.PlaceName)
+		logger.Debug("Notifying progress with search status", "place_name", res.doc.PlaceName)
 		_ = serverSession.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
 			ProgressToken: req.Params.GetProgressToken(),
-			// Value 필드는 MCP 프로토콜의 ProgressNotificationParams에 없으므로 Message 필드를 사용합니다.
-			Message: string(resultBytes),
+			Message:       fmt.Sprintf("%d/%d places processed", processed, len(mapDocuments)),
 		})
 	}
 
 	logger.Info("Search complete. All results have been streamed.")
+	content = append(content, &mcp.TextContent{Text: "Search complete. All results are returned above as embedded resources."})
+
+	if historyStore != nil {
+		placesJSON, err := json.Marshal(placeNames)
+		if err != nil {
+			logger.Warn("Failed to marshal place names for history", "error", err)
+		} else if err := historyStore.Record(ctx, options.Query, processed, time.Since(start), string(placesJSON)); err != nil {
+			logger.Warn("Failed to record search history", "query", options.Query, "error", err)
+		}
+	}
+
 	return &mcp.CallToolResult{
-		Content: []mcp.Content{&mcp.TextContent{Text: "Search complete. All results have been streamed."}},
+		Content: content,
 	}, nil, nil
 }
 
+// --- 도구 등록 ---
+
+// toolRegistration attaches one MCP tool (or resource template) to a server. Keeping these in a
+// slice lets new endpoints be added without touching main.
+type toolRegistration func(s *mcp.Server)
+
+func registerSearchTool(s *mcp.Server) {
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "kakao_map_place_recommender",
+		Description: "Recommends relevant places in South Korea based on user queries.",
+	}, searchTool)
+}
+
+var registeredTools = []toolRegistration{
+	registerSearchTool,
+	registerCoordToAddressTool,
+	registerAddressToCoordTool,
+	registerPlaceResources,
+	registerHistoryTool,
+}
+
 // --- HTTP 로깅 미들웨어 ---
 func loggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -270,15 +406,24 @@ func main() {
 		os.Exit(1)
 	}
 
-	mcpServer := mcp.NewServer(&mcp.Implementation{
+	responseCache = initCache()
+	kakaoRateLimiter = initRateLimiter()
+
+	var err error
+	historyStore, err = initHistoryStore()
+	if err != nil {
+		logger.Error("Failed to initialize activity history store", "error", err)
+		os.Exit(1)
+	}
+
+	mcpServer = mcp.NewServer(&mcp.Implementation{
 		Name:    "mcp-server-kakao-map-go",
 		Version: "0.0.1",
 	}, nil)
 
-	mcp.AddTool(mcpServer, &mcp.Tool{
-		Name:        "kakao_map_place_recommender",
-		Description: "Recommends relevant places in South Korea based on user queries.",
-	}, searchTool)
+	for _, register := range registeredTools {
+		register(mcpServer)
+	}
 
 	handler := mcp.NewStreamableHTTPHandler(func(r *http.Request) *mcp.Server {
 		return mcpServer
@@ -286,6 +431,7 @@ func main() {
 
 	mux := http.NewServeMux()
 	mux.Handle("/", loggingMiddleware(handler))
+	mux.Handle("/metrics", promhttp.Handler())
 
 	port := "8080"
 	logger.Info("MCP server with SSE is running", "url", "http://localhost:"+port)
@@ -0,0 +1,17 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	cacheHits = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "kakao_cache_hits_total",
+		Help: "Number of Kakao API responses served from cache.",
+	})
+	cacheMisses = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "kakao_cache_misses_total",
+		Help: "Number of Kakao API requests that missed the cache.",
+	})
+)
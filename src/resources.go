@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// placeResourceURIPrefix namespaces every place emitted by searchTool so
+// clients can address them as kakao-place://{place_id}.
+const placeResourceURIPrefix = "kakao-place://"
+
+const (
+	placeResourceCacheCapacity = 1000
+	placeResourceTTL           = 24 * time.Hour
+)
+
+// placeResourceStore holds the places searchTool has fetched so ReadResource
+// can serve them back by URI after the tool call has returned. It's an LRU
+// with TTL, same as the Kakao response cache, so it doesn't grow without
+// bound over a long-running server's lifetime. Evicted entries are also
+// unregistered from the live server. An entry whose TTL has passed is only
+// evicted when it is next read or pushed off the LRU, so until then
+// resources/list can still advertise a URI that ReadResource no longer serves.
+type placeResourceStore struct {
+	cache *MemoryCache
+}
+
+func newPlaceResourceStore() *placeResourceStore {
+	cache := NewMemoryCache(placeResourceCacheCapacity)
+	cache.SetEvictHandler(func(placeID string) {
+		if mcpServer != nil {
+			mcpServer.RemoveResources(placeResourceURI(placeID))
+		}
+	})
+	return &placeResourceStore{cache: cache}
+}
+
+var placeResources = newPlaceResourceStore()
+
+func (s *placeResourceStore) put(ctx context.Context, placeID string, result KakaoLocalSearchResult) error {
+	body, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal place resource: %w", err)
+	}
+	return s.cache.Set(ctx, placeID, body, placeResourceTTL)
+}
+
+func (s *placeResourceStore) get(ctx context.Context, placeID string) (KakaoLocalSearchResult, bool, error) {
+	body, ok, err := s.cache.Get(ctx, placeID)
+	if err != nil || !ok {
+		return KakaoLocalSearchResult{}, false, err
+	}
+	var result KakaoLocalSearchResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return KakaoLocalSearchResult{}, false, fmt.Errorf("failed to unmarshal place resource: %w", err)
+	}
+	return result, true, nil
+}
+
+// placeIDFor returns the Kakao-assigned place id, falling back to a stable
+// hash of the place name and address for documents that omit it.
+func placeIDFor(doc MapDocument) string {
+	if doc.ID != "" {
+		return doc.ID
+	}
+	sum := sha1.Sum([]byte(doc.PlaceName + "|" + doc.AddressName))
+	return hex.EncodeToString(sum[:])
+}
+
+func placeResourceURI(placeID string) string {
+	return placeResourceURIPrefix + placeID
+}
+
+// readPlaceResource implements mcp's ReadResource handler for kakao-place://
+// resources, both the ones discoverable via resources/list and the
+// resources/templates/list pattern.
+func readPlaceResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	placeID := strings.TrimPrefix(req.Params.URI, placeResourceURIPrefix)
+	result, ok, err := placeResources.get(ctx, placeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up place resource: %w", err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown place resource: %s", req.Params.URI)
+	}
+
+	body, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal place resource: %w", err)
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      req.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(body),
+			},
+		},
+	}, nil
+}
+
+func registerPlaceResources(s *mcp.Server) {
+	s.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: placeResourceURIPrefix + "{place_id}",
+		Name:        "kakao-place",
+		Description: "A place returned by kakao_map_place_recommender, including its comments and image.",
+		MIMEType:    "application/json",
+	}, readPlaceResource)
+}
+
+// publishPlaceResource stores a place result and, when the server is
+// available, registers it as a concrete mcp.Resource so resources/list (not
+// just resources/templates/list) can discover it.
+func publishPlaceResource(ctx context.Context, placeID string, result KakaoLocalSearchResult) error {
+	if err := placeResources.put(ctx, placeID, result); err != nil {
+		return err
+	}
+
+	if mcpServer != nil {
+		mcpServer.AddResource(&mcp.Resource{
+			URI:         placeResourceURI(placeID),
+			Name:        result.PlaceName,
+			Description: fmt.Sprintf("Kakao place: %s (%s)", result.PlaceName, result.AddressName),
+			MIMEType:    "application/json",
+		}, readPlaceResource)
+	}
+
+	return nil
+}